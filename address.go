@@ -0,0 +1,148 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package bitcoin
+
+import "errors"
+
+// AddressType selects which kind of payload a Base58Check address wraps.
+type AddressType int
+
+const (
+	AddressTypeP2PKH AddressType = iota
+	AddressTypeP2SH
+)
+
+// Network groups the version bytes and Bech32 human-readable part that
+// distinguish Bitcoin's mainnet and testnet address and private-key
+// encodings.
+type Network struct {
+	Name         string
+	P2PKHVersion byte
+	P2SHVersion  byte
+	WIFVersion   byte
+	Bech32HRP    string
+}
+
+var (
+	// MainNet is the production Bitcoin network.
+	MainNet = &Network{Name: "mainnet", P2PKHVersion: 0x00, P2SHVersion: 0x05, WIFVersion: 0x80, Bech32HRP: "bc"}
+
+	// TestNet is the public Bitcoin test network.
+	TestNet = &Network{Name: "testnet", P2PKHVersion: 0x6F, P2SHVersion: 0xC4, WIFVersion: 0xEF, Bech32HRP: "tb"}
+
+	knownNetworks = []*Network{MainNet, TestNet}
+
+	ErrUnknownAddressVersion = errors.New("unknown address version byte")
+	ErrUnknownWIFVersion     = errors.New("unknown WIF version byte")
+)
+
+// versionForType returns the version byte Network net uses for AddressType typ.
+func versionForType(net *Network, typ AddressType) (byte, error) {
+	switch typ {
+	case AddressTypeP2PKH:
+		return net.P2PKHVersion, nil
+	case AddressTypeP2SH:
+		return net.P2SHVersion, nil
+	default:
+		return 0, errors.New("unknown address type")
+	}
+}
+
+// EncodeAddress encodes a Hash160 as a Base58Check address for the given
+// network and address type (P2PKH or P2SH).
+func EncodeAddress(hash Hash160, net *Network, typ AddressType) string {
+	version, err := versionForType(net, typ)
+	if err != nil {
+		panic(err) // AddressType is a closed enum; this means an invalid constant was passed.
+	}
+
+	payload := make([]byte, 1+len(hash))
+	payload[0] = version
+	copy(payload[1:], hash[:])
+
+	return EncodeBase58Check(payload)
+}
+
+// DecodeAddress decodes a Base58Check address, identifying its network and
+// address type from the version byte.
+func DecodeAddress(s string) (Hash160, *Network, AddressType, error) {
+	var hash160 Hash160
+
+	decoded, err := DecodeBase58Check(s)
+	if err != nil {
+		return hash160, nil, 0, err
+	}
+
+	if len(decoded) != len(hash160)+1 {
+		return hash160, nil, 0, errors.New("invalid address length")
+	}
+
+	version := decoded[0]
+	for _, net := range knownNetworks {
+		switch version {
+		case net.P2PKHVersion:
+			copy(hash160[:], decoded[1:])
+			return hash160, net, AddressTypeP2PKH, nil
+		case net.P2SHVersion:
+			copy(hash160[:], decoded[1:])
+			return hash160, net, AddressTypeP2SH, nil
+		}
+	}
+
+	return hash160, nil, 0, ErrUnknownAddressVersion
+}
+
+// EncodeWIF encodes a 32-byte secp256k1 private key in Wallet Import Format.
+// When compressed is true, the 0x01 suffix marking a compressed public key is
+// appended before the checksum.
+func EncodeWIF(privKey []byte, net *Network, compressed bool) (string, error) {
+	if len(privKey) != 32 {
+		return "", errors.New("private key must be 32 bytes")
+	}
+
+	payload := make([]byte, 0, 34)
+	payload = append(payload, net.WIFVersion)
+	payload = append(payload, privKey...)
+	if compressed {
+		payload = append(payload, 0x01)
+	}
+
+	return EncodeBase58Check(payload), nil
+}
+
+// DecodeWIF decodes a Wallet Import Format string into its private key,
+// network, and compressed-pubkey flag.
+func DecodeWIF(s string) (privKey []byte, net *Network, compressed bool, err error) {
+	decoded, err := DecodeBase58Check(s)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	switch len(decoded) {
+	case 33:
+		compressed = false
+	case 34:
+		if decoded[33] != 0x01 {
+			return nil, nil, false, errors.New("invalid compressed-pubkey suffix")
+		}
+		compressed = true
+	default:
+		return nil, nil, false, errors.New("invalid WIF length")
+	}
+
+	version := decoded[0]
+	for _, n := range knownNetworks {
+		if n.WIFVersion == version {
+			net = n
+			break
+		}
+	}
+	if net == nil {
+		return nil, nil, false, ErrUnknownWIFVersion
+	}
+
+	privKey = append([]byte(nil), decoded[1:33]...)
+	return privKey, net, compressed, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// addressVectors cover all four network/address-type combinations with
+// known Base58Check addresses.
+var addressVectors = []struct {
+	name    string
+	hash160 string // hex
+	net     *Network
+	typ     AddressType
+	want    string
+}{
+	{"mainnet P2PKH", "751e76e8199196d454941c45d1b3a323f1433bd6", MainNet, AddressTypeP2PKH, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"},
+	{"mainnet P2SH", "e9c3dd0c07aac76179ebc76a6c78d4d67c6c160a", MainNet, AddressTypeP2SH, "3P14159f73E4gFr7JterCCQh9QjiTjiZrG"},
+	{"testnet P2PKH", "243f1394f44554f4ce3fd68649c19adc483ce924", TestNet, AddressTypeP2PKH, "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"},
+	{"testnet P2SH", "c579342c2c4c9220205e2cdc285617040c924a0a", TestNet, AddressTypeP2SH, "2NBFNJTktNa7GZusGbDbGKRZTxdK9VVez3n"},
+}
+
+func TestEncodeAddressVectors(t *testing.T) {
+	for _, v := range addressVectors {
+		raw, err := hex.DecodeString(v.hash160)
+		if err != nil {
+			t.Fatalf("%s: invalid hash160 fixture: %v", v.name, err)
+		}
+		var hash160 Hash160
+		copy(hash160[:], raw)
+
+		if got := EncodeAddress(hash160, v.net, v.typ); got != v.want {
+			t.Errorf("%s: EncodeAddress = %q, want %q", v.name, got, v.want)
+		}
+	}
+}
+
+func TestDecodeAddressVectors(t *testing.T) {
+	for _, v := range addressVectors {
+		want, err := hex.DecodeString(v.hash160)
+		if err != nil {
+			t.Fatalf("%s: invalid hash160 fixture: %v", v.name, err)
+		}
+
+		hash160, net, typ, err := DecodeAddress(v.want)
+		if err != nil {
+			t.Fatalf("%s: DecodeAddress(%q) returned error: %v", v.name, v.want, err)
+		}
+		if !bytes.Equal(hash160[:], want) {
+			t.Errorf("%s: DecodeAddress(%q) hash160 = %x, want %x", v.name, v.want, hash160[:], want)
+		}
+		if net != v.net {
+			t.Errorf("%s: DecodeAddress(%q) net = %v, want %v", v.name, v.want, net, v.net)
+		}
+		if typ != v.typ {
+			t.Errorf("%s: DecodeAddress(%q) type = %v, want %v", v.name, v.want, typ, v.typ)
+		}
+	}
+}
+
+func TestDecodeAddressUnknownVersion(t *testing.T) {
+	// A payload whose version byte (0xFF) matches neither mainnet nor
+	// testnet P2PKH/P2SH.
+	payload := append([]byte{0xFF}, make([]byte, 20)...)
+	bogus := EncodeBase58Check(payload)
+
+	if _, _, _, err := DecodeAddress(bogus); err != ErrUnknownAddressVersion {
+		t.Errorf("DecodeAddress(%q) = %v, want ErrUnknownAddressVersion", bogus, err)
+	}
+}
+
+// wifVectors cover mainnet/testnet, compressed/uncompressed, for the
+// private key 0x00..01.
+var wifVectors = []struct {
+	name       string
+	privKey    string // hex, 32 bytes
+	net        *Network
+	compressed bool
+	want       string
+}{
+	{"mainnet uncompressed", "0000000000000000000000000000000000000000000000000000000000000001", MainNet, false, "5HpHagT65TZzG1PH3CSu63k8DbpvD8s5ip4nEB3kEsreAnchuDf"},
+	{"mainnet compressed", "0000000000000000000000000000000000000000000000000000000000000001", MainNet, true, "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn"},
+	{"testnet uncompressed", "0000000000000000000000000000000000000000000000000000000000000001", TestNet, false, "91avARGdfge8E4tZfYLoxeJ5sGBdNJQH4kvjJoQFacbgwmaKkrx"},
+	{"testnet compressed", "0000000000000000000000000000000000000000000000000000000000000001", TestNet, true, "cMahea7zqjxrtgAbB7LSGbcQUr1uX1ojuat9jZodMN87JcbXMTcA"},
+}
+
+func TestEncodeWIFVectors(t *testing.T) {
+	for _, v := range wifVectors {
+		privKey, err := hex.DecodeString(v.privKey)
+		if err != nil {
+			t.Fatalf("%s: invalid private key fixture: %v", v.name, err)
+		}
+
+		got, err := EncodeWIF(privKey, v.net, v.compressed)
+		if err != nil {
+			t.Fatalf("%s: EncodeWIF returned error: %v", v.name, err)
+		}
+		if got != v.want {
+			t.Errorf("%s: EncodeWIF = %q, want %q", v.name, got, v.want)
+		}
+	}
+}
+
+func TestDecodeWIFVectors(t *testing.T) {
+	for _, v := range wifVectors {
+		want, err := hex.DecodeString(v.privKey)
+		if err != nil {
+			t.Fatalf("%s: invalid private key fixture: %v", v.name, err)
+		}
+
+		privKey, net, compressed, err := DecodeWIF(v.want)
+		if err != nil {
+			t.Fatalf("%s: DecodeWIF(%q) returned error: %v", v.name, v.want, err)
+		}
+		if !bytes.Equal(privKey, want) {
+			t.Errorf("%s: DecodeWIF(%q) privKey = %x, want %x", v.name, v.want, privKey, want)
+		}
+		if net != v.net {
+			t.Errorf("%s: DecodeWIF(%q) net = %v, want %v", v.name, v.want, net, v.net)
+		}
+		if compressed != v.compressed {
+			t.Errorf("%s: DecodeWIF(%q) compressed = %v, want %v", v.name, v.want, compressed, v.compressed)
+		}
+	}
+}
+
+func TestDecodeWIFUnknownVersion(t *testing.T) {
+	payload := append([]byte{0xFF}, make([]byte, 32)...)
+	bogus := EncodeBase58Check(payload)
+
+	if _, _, _, err := DecodeWIF(bogus); err != ErrUnknownWIFVersion {
+		t.Errorf("DecodeWIF(%q) = %v, want ErrUnknownWIFVersion", bogus, err)
+	}
+}
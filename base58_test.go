@@ -0,0 +1,141 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package bitcoin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// base58Vectors are well-known Base58 vectors, largely drawn from the
+// Rosetta Code Base58 tasks.
+var base58Vectors = []struct {
+	name  string
+	input []byte
+	want  string
+}{
+	{"empty", []byte{}, ""},
+	{"hello world", []byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+	{"leading zero byte", []byte{0x00, 0x01}, "12"},
+	{"all zero bytes", []byte{0x00, 0x00, 0x00, 0x00}, "1111"},
+}
+
+// TestDecodeBase58CheckNullAddress decodes the "null address" Base58Check
+// vector: version 0x00 followed by a 20-byte all-zero Hash160.
+func TestDecodeBase58CheckNullAddress(t *testing.T) {
+	const nullAddress = "1111111111111111111114oLvT2"
+
+	payload, err := DecodeBase58Check(nullAddress)
+	if err != nil {
+		t.Fatalf("DecodeBase58Check(%q) returned error: %v", nullAddress, err)
+	}
+
+	want := make([]byte, 21)
+	if !bytes.Equal(payload, want) {
+		t.Errorf("DecodeBase58Check(%q) = %x, want %x", nullAddress, payload, want)
+	}
+}
+
+func TestEncodeBase58Vectors(t *testing.T) {
+	for _, v := range base58Vectors {
+		got := EncodeBase58(v.input)
+		if got != v.want {
+			t.Errorf("%s: EncodeBase58(%x) = %q, want %q", v.name, v.input, got, v.want)
+		}
+	}
+}
+
+func TestDecodeBase58Vectors(t *testing.T) {
+	for _, v := range base58Vectors {
+		got, err := DecodeBase58(v.want)
+		if err != nil {
+			t.Errorf("%s: DecodeBase58(%q) returned error: %v", v.name, v.want, err)
+			continue
+		}
+		if !bytes.Equal(got, v.input) {
+			t.Errorf("%s: DecodeBase58(%q) = %x, want %x", v.name, v.want, got, v.input)
+		}
+	}
+}
+
+func TestDecodeBase58RoundTrip(t *testing.T) {
+	const addr = "1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i"
+	decoded, err := DecodeBase58(addr)
+	if err != nil {
+		t.Fatalf("DecodeBase58(%q) returned error: %v", addr, err)
+	}
+	if got := EncodeBase58(decoded); got != addr {
+		t.Errorf("round trip mismatch: got %q, want %q", got, addr)
+	}
+}
+
+func TestDecodeBase58Invalid(t *testing.T) {
+	const valid = "1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i"
+
+	for i := range valid {
+		mutated := []byte(valid)
+		mutated[i] = '0' // '0' is excluded from the base58 alphabet
+		if _, err := DecodeBase58(string(mutated)); err != ErrInvalidBase58 {
+			t.Errorf("DecodeBase58(%q) = %v, want ErrInvalidBase58", mutated, err)
+		}
+	}
+}
+
+func TestDecodeBase58Empty(t *testing.T) {
+	got, err := DecodeBase58("")
+	if err != nil || got != nil {
+		t.Errorf("DecodeBase58(\"\") = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestDecodeBase58AllOnes(t *testing.T) {
+	for _, s := range []string{"1", "11", "111111111111111111111111111111111111111"} {
+		got, err := DecodeBase58(s)
+		if err != nil {
+			t.Fatalf("DecodeBase58(%q) returned error: %v", s, err)
+		}
+		if len(got) != len(s) {
+			t.Errorf("DecodeBase58(%q) = %x (len %d), want %d zero bytes", s, got, len(got), len(s))
+		}
+		for _, b := range got {
+			if b != 0 {
+				t.Errorf("DecodeBase58(%q) = %x, want all-zero bytes", s, got)
+				break
+			}
+		}
+	}
+}
+
+func TestEncodeBase58Into(t *testing.T) {
+	for _, v := range base58Vectors {
+		dst := make([]byte, len(v.input)*138/100+1)
+		n := EncodeBase58Into(dst, v.input)
+		if got := string(dst[:n]); got != v.want {
+			t.Errorf("%s: EncodeBase58Into(%x) = %q, want %q", v.name, v.input, got, v.want)
+		}
+	}
+}
+
+func FuzzDecodeBase58(f *testing.F) {
+	for _, v := range base58Vectors {
+		f.Add(v.want)
+	}
+	f.Add("1AGNa15ZQXAZUgFiqJ2i7Z2DPU2J6hW62i")
+	f.Add("1111111111111111111114oLvT2")
+	f.Add("0OIl") // characters excluded from the base58 alphabet
+
+	f.Fuzz(func(t *testing.T, s string) {
+		decoded, err := DecodeBase58(s)
+		if err != nil {
+			return
+		}
+		// DecodeBase58 trims surrounding whitespace before decoding, so the
+		// round trip is only expected to match the trimmed input.
+		if got := EncodeBase58(decoded); got != strings.TrimSpace(s) {
+			t.Errorf("EncodeBase58(DecodeBase58(%q)) = %q, want %q", s, got, strings.TrimSpace(s))
+		}
+	})
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package bitcoin
+
+import (
+	"errors"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32Const and bech32mConst are the final XOR constants for the two
+// checksum variants: BIP-173 Bech32 (witness version 0) and BIP-350 Bech32m
+// (witness version 1-16).
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+var (
+	ErrBech32MixedCase       = errors.New("bech32: string contains mixed-case characters")
+	ErrBech32InvalidChar     = errors.New("bech32: invalid character in data part")
+	ErrBech32NoSeparator     = errors.New("bech32: no separator character")
+	ErrBech32BadChecksum     = errors.New("bech32: invalid checksum")
+	ErrInvalidWitnessVersion = errors.New("bech32: invalid witness version")
+	ErrInvalidWitnessProgram = errors.New("bech32: invalid witness program length")
+)
+
+// bech32Polymod computes the Bech32 checksum polynomial over GF(32).
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := byte(chk >> 25)
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// hrpExpand expands a human-readable part into the form used by the
+// checksum, per BIP-173.
+func hrpExpand(hrp string) []byte {
+	ret := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]>>5)
+	}
+	ret = append(ret, 0)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]&31)
+	}
+	return ret
+}
+
+func bech32VerifyChecksum(hrp string, data []byte, constant uint32) bool {
+	values := append(hrpExpand(hrp), data...)
+	return bech32Polymod(values) == constant
+}
+
+func bech32CreateChecksum(hrp string, data []byte, constant uint32) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(values) ^ constant
+
+	checksum := make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+// bech32Encode encodes hrp and the 5-bit data words (without a checksum) as
+// a Bech32 or Bech32m string, depending on constant.
+func bech32Encode(hrp string, data []byte, constant uint32) (string, error) {
+	if hrp == "" {
+		return "", errors.New("bech32: empty human-readable part")
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", errors.New("bech32: invalid human-readable part character")
+		}
+	}
+
+	combined := append(append([]byte{}, data...), bech32CreateChecksum(hrp, data, constant)...)
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range combined {
+		if int(d) >= len(bech32Charset) {
+			return "", ErrBech32InvalidChar
+		}
+		sb.WriteByte(bech32Charset[d])
+	}
+	return sb.String(), nil
+}
+
+// bech32Decode splits s into its human-readable part and 5-bit data words
+// (including the trailing 6-word checksum), enforcing the no-mixed-case
+// rule. It does not validate the checksum.
+func bech32Decode(s string) (hrp string, data []byte, err error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, ErrBech32MixedCase
+	}
+	lower := strings.ToLower(s)
+
+	pos := strings.LastIndexByte(lower, '1')
+	if pos < 1 || pos+7 > len(lower) {
+		return "", nil, ErrBech32NoSeparator
+	}
+
+	hrp = lower[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, errors.New("bech32: invalid human-readable part character")
+		}
+	}
+
+	dataPart := lower[pos+1:]
+	data = make([]byte, len(dataPart))
+	for i := 0; i < len(dataPart); i++ {
+		d := strings.IndexByte(bech32Charset, dataPart[i])
+		if d == -1 {
+			return "", nil, ErrBech32InvalidChar
+		}
+		data[i] = byte(d)
+	}
+
+	return hrp, data, nil
+}
+
+// convertBits regroups a slice of fromBits-wide values into toBits-wide
+// values, used to move between the 8-bit witness program and the 5-bit
+// Bech32 alphabet.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		ret  []byte
+	)
+	maxv := uint32(1)<<toBits - 1
+
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errors.New("bech32: input value out of range")
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			ret = append(ret, byte(acc>>bits)&byte(maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			ret = append(ret, byte(acc<<(toBits-bits))&byte(maxv))
+		}
+	} else if bits >= fromBits || byte(acc<<(toBits-bits))&byte(maxv) != 0 {
+		return nil, errors.New("bech32: invalid padding")
+	}
+
+	return ret, nil
+}
+
+// EncodeSegWit encodes a witness version and program as a native SegWit
+// address (e.g. "bc1..." or "tb1..."), using Bech32 for version 0 and
+// Bech32m for versions 1-16, per BIP-173/BIP-350.
+func EncodeSegWit(hrp string, version byte, program []byte) (string, error) {
+	if version > 16 {
+		return "", ErrInvalidWitnessVersion
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", ErrInvalidWitnessProgram
+	}
+
+	data, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	values := append([]byte{version}, data...)
+
+	constant := uint32(bech32Const)
+	if version != 0 {
+		constant = bech32mConst
+	}
+	return bech32Encode(hrp, values, constant)
+}
+
+// DecodeSegWit decodes a native SegWit address into its human-readable
+// part, witness version, and witness program.
+func DecodeSegWit(s string) (hrp string, version byte, program []byte, err error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(data) < 7 {
+		return "", 0, nil, errors.New("bech32: data too short")
+	}
+
+	version = data[0]
+	if version > 16 {
+		return "", 0, nil, ErrInvalidWitnessVersion
+	}
+
+	constant := uint32(bech32Const)
+	if version != 0 {
+		constant = bech32mConst
+	}
+	if !bech32VerifyChecksum(hrp, data, constant) {
+		return "", 0, nil, ErrBech32BadChecksum
+	}
+
+	program, err = convertBits(data[1:len(data)-6], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", 0, nil, ErrInvalidWitnessProgram
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "", 0, nil, ErrInvalidWitnessProgram
+	}
+
+	return hrp, version, program, nil
+}
+
+// ParsedAddress is the result of ParseAddress: either a legacy Base58Check
+// address (Hash160/Type set, IsSegWit false) or a native SegWit address
+// (WitnessVersion/WitnessProgram set, IsSegWit true).
+type ParsedAddress struct {
+	Network  *Network
+	IsSegWit bool
+
+	// Legacy (Base58Check) fields.
+	Hash160 Hash160
+	Type    AddressType
+
+	// SegWit (Bech32/Bech32m) fields.
+	WitnessVersion byte
+	WitnessProgram []byte
+}
+
+// ParseAddress parses either a legacy Base58Check address or a native
+// SegWit address, dispatching on whether s looks like a known Bech32
+// human-readable part.
+func ParseAddress(s string) (*ParsedAddress, error) {
+	lower := strings.ToLower(s)
+	for _, net := range knownNetworks {
+		if strings.HasPrefix(lower, net.Bech32HRP+"1") {
+			_, version, program, err := DecodeSegWit(s)
+			if err != nil {
+				return nil, err
+			}
+			return &ParsedAddress{
+				Network:        net,
+				IsSegWit:       true,
+				WitnessVersion: version,
+				WitnessProgram: program,
+			}, nil
+		}
+	}
+
+	hash160, net, typ, err := DecodeAddress(s)
+	if err != nil {
+		return nil, err
+	}
+	return &ParsedAddress{
+		Network: net,
+		Hash160: hash160,
+		Type:    typ,
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// segwitVectors cover both checksum variants (Bech32 for witness version 0,
+// Bech32m for versions 1-16) and both known HRPs, per BIP-173/BIP-350.
+var segwitVectors = []struct {
+	name    string
+	hrp     string
+	version byte
+	program string // hex
+	want    string
+}{
+	{"v0 P2WPKH mainnet", "bc", 0, "751e76e8199196d454941c45d1b3a323f1433bd6", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+	{"v0 P2WSH testnet", "tb", 0, "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"},
+	{"v1 mainnet (Bech32m)", "bc", 1, "1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262", "bc1prp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qj0fj5d"},
+	{"v16 mainnet (Bech32m)", "bc", 16, "7511", "bc1sw5gsedwxen"},
+}
+
+func TestEncodeSegWitVectors(t *testing.T) {
+	for _, v := range segwitVectors {
+		program, err := hex.DecodeString(v.program)
+		if err != nil {
+			t.Fatalf("%s: invalid program fixture: %v", v.name, err)
+		}
+
+		got, err := EncodeSegWit(v.hrp, v.version, program)
+		if err != nil {
+			t.Fatalf("%s: EncodeSegWit returned error: %v", v.name, err)
+		}
+		if got != v.want {
+			t.Errorf("%s: EncodeSegWit = %q, want %q", v.name, got, v.want)
+		}
+	}
+}
+
+func TestDecodeSegWitVectors(t *testing.T) {
+	for _, v := range segwitVectors {
+		want, err := hex.DecodeString(v.program)
+		if err != nil {
+			t.Fatalf("%s: invalid program fixture: %v", v.name, err)
+		}
+
+		hrp, version, program, err := DecodeSegWit(v.want)
+		if err != nil {
+			t.Fatalf("%s: DecodeSegWit(%q) returned error: %v", v.name, v.want, err)
+		}
+		if hrp != v.hrp {
+			t.Errorf("%s: DecodeSegWit(%q) hrp = %q, want %q", v.name, v.want, hrp, v.hrp)
+		}
+		if version != v.version {
+			t.Errorf("%s: DecodeSegWit(%q) version = %d, want %d", v.name, v.want, version, v.version)
+		}
+		if !bytes.Equal(program, want) {
+			t.Errorf("%s: DecodeSegWit(%q) program = %x, want %x", v.name, v.want, program, want)
+		}
+	}
+}
+
+// TestDecodeSegWitWrongChecksumVariant checks that a witness-version-0
+// address encoded with the Bech32m constant instead of the Bech32 constant
+// is rejected.
+func TestDecodeSegWitWrongChecksumVariant(t *testing.T) {
+	program, err := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6")
+	if err != nil {
+		t.Fatalf("invalid program fixture: %v", err)
+	}
+
+	data, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		t.Fatalf("convertBits returned error: %v", err)
+	}
+	values := append([]byte{0}, data...)
+
+	// Encode a v0 program using the Bech32m constant instead of Bech32.
+	bogus, err := bech32Encode("bc", values, bech32mConst)
+	if err != nil {
+		t.Fatalf("bech32Encode returned error: %v", err)
+	}
+
+	if _, _, _, err := DecodeSegWit(bogus); err != ErrBech32BadChecksum {
+		t.Errorf("DecodeSegWit(%q) = %v, want ErrBech32BadChecksum", bogus, err)
+	}
+}
+
+func TestParseAddressSegWit(t *testing.T) {
+	parsed, err := ParseAddress("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4")
+	if err != nil {
+		t.Fatalf("ParseAddress returned error: %v", err)
+	}
+	if !parsed.IsSegWit {
+		t.Fatal("ParseAddress: IsSegWit = false, want true")
+	}
+	if parsed.Network != MainNet {
+		t.Errorf("ParseAddress: Network = %v, want MainNet", parsed.Network)
+	}
+	if parsed.WitnessVersion != 0 {
+		t.Errorf("ParseAddress: WitnessVersion = %d, want 0", parsed.WitnessVersion)
+	}
+}
+
+func TestParseAddressLegacy(t *testing.T) {
+	const addr = "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"
+
+	parsed, err := ParseAddress(addr)
+	if err != nil {
+		t.Fatalf("ParseAddress returned error: %v", err)
+	}
+	if parsed.IsSegWit {
+		t.Fatal("ParseAddress: IsSegWit = true, want false")
+	}
+	if parsed.Network != MainNet || parsed.Type != AddressTypeP2PKH {
+		t.Errorf("ParseAddress: Network = %v, Type = %v, want MainNet/P2PKH", parsed.Network, parsed.Type)
+	}
+}
@@ -4,14 +4,15 @@
 //
 // Go implementation of the original Bitcoin commit by Satoshi Nakamoto
 
-package main
+package bitcoin
 
 import (
 	"crypto/sha256"
 	"errors"
-	"fmt"
 	"math/big"
 	"strings"
+
+	"golang.org/x/crypto/ripemd160"
 )
 
 // Why base-58 instead of standard base-64 encoding?
@@ -22,113 +23,130 @@ import (
 // - Doubleclicking selects the whole number as one word if it's all alphanumeric.
 
 const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-const addressVersion = 0
 
 var (
-	bigRadix   = big.NewInt(58)
-	bigZero    = big.NewInt(0)
-	ErrInvalidBase58 = errors.New("invalid base58 character")
+	ErrInvalidBase58   = errors.New("invalid base58 character")
+	ErrPointNotOnCurve = errors.New("point is not on secp256k1")
 )
 
+// secp256k1 curve parameters: y^2 = x^3 + 7 (mod p)
+var (
+	secp256k1P = mustParseHex("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F")
+	secp256k1B = big.NewInt(7)
+)
+
+func mustParseHex(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex constant: " + s)
+	}
+	return n
+}
+
 // Hash160 represents a 160-bit hash (20 bytes)
 type Hash160 [20]byte
 
-// Hash256 represents a 256-bit hash (32 bytes) 
+// Hash256 represents a 256-bit hash (32 bytes)
 type Hash256 [32]byte
 
-// EncodeBase58 encodes a byte slice to base58 string
+// EncodeBase58 encodes a byte slice to a base58 string.
 func EncodeBase58(input []byte) string {
 	if len(input) == 0 {
 		return ""
 	}
 
-	// Convert big endian data to little endian
-	// Extra zero at the end make sure bignum will interpret as a positive number
-	inputReversed := make([]byte, len(input)+1)
-	for i, b := range input {
-		inputReversed[len(input)-1-i] = b
-	}
-	inputReversed[len(input)] = 0
-
-	// Convert little endian data to bignum
-	bn := new(big.Int).SetBytes(reverse(inputReversed))
+	dst := make([]byte, len(input)*138/100+1)
+	n := EncodeBase58Into(dst, input)
+	return string(dst[:n])
+}
 
-	// Convert bignum to string
-	var result strings.Builder
-	result.Grow((len(input)*138)/100 + 1) // Reserve space
+// EncodeBase58Into encodes src as base58 into dst, using the byte-array
+// long-division algorithm (repeated divide-by-58 over a working buffer) in
+// place of one math/big operation per input byte. dst must have a length of
+// at least len(src)*138/100+1 (its capacity beyond that length is not used);
+// the number of bytes written is returned.
+func EncodeBase58Into(dst, src []byte) int {
+	if len(src) == 0 {
+		return 0
+	}
 
-	for bn.Cmp(bigZero) > 0 {
-		mod := new(big.Int)
-		bn.DivMod(bn, bigRadix, mod)
-		result.WriteByte(base58Alphabet[mod.Int64()])
+	zeroes := 0
+	for zeroes < len(src) && src[zeroes] == 0 {
+		zeroes++
 	}
 
-	// Leading zeroes encoded as base58 zeros
-	for _, b := range input {
-		if b != 0 {
-			break
+	size := (len(src)-zeroes)*138/100 + 1
+	buf := make([]byte, size) // base-58 digits, most-significant first, zero-padded
+	length := 0
+
+	for _, b := range src[zeroes:] {
+		carry := int(b)
+		digits := 0
+		for k := size - 1; (carry != 0 || digits < length) && k >= 0; k-- {
+			carry += 256 * int(buf[k])
+			buf[k] = byte(carry % 58)
+			carry /= 58
+			digits++
 		}
-		result.WriteByte(base58Alphabet[0])
+		length = digits
+	}
+
+	skip := size - length
+	for skip < size && buf[skip] == 0 {
+		skip++
 	}
 
-	// Convert little endian string to big endian
-	return reverseString(result.String())
+	n := 0
+	for ; n < zeroes; n++ {
+		dst[n] = base58Alphabet[0]
+	}
+	for _, d := range buf[skip:] {
+		dst[n] = base58Alphabet[d]
+		n++
+	}
+	return n
 }
 
-// DecodeBase58 decodes a base58 string to byte slice
+// DecodeBase58 decodes a base58 string into the byte slice it encodes.
 func DecodeBase58(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
 	if len(s) == 0 {
 		return nil, nil
 	}
 
-	// Skip leading whitespace
-	s = strings.TrimLeft(s, " \t\n\r")
-	if len(s) == 0 {
-		return nil, nil
+	zeroes := 0
+	for zeroes < len(s) && s[zeroes] == base58Alphabet[0] {
+		zeroes++
 	}
 
-	bn := new(big.Int)
-	bnChar := new(big.Int)
+	size := (len(s)-zeroes)*733/1000 + 1 // log(58)/log(256), rounded up
+	buf := make([]byte, size)            // base-256 digits, most-significant first, zero-padded
+	length := 0
 
-	// Convert big endian string to bignum
-	for _, c := range s {
-		idx := strings.IndexRune(base58Alphabet, c)
+	for i := zeroes; i < len(s); i++ {
+		idx := strings.IndexByte(base58Alphabet, s[i])
 		if idx == -1 {
-			// Check if remaining characters are whitespace
-			remaining := strings.TrimLeft(string(c), " \t\n\r")
-			if len(remaining) > 0 {
-				return nil, ErrInvalidBase58
-			}
-			break
+			return nil, ErrInvalidBase58
 		}
-		bnChar.SetInt64(int64(idx))
-		bn.Mul(bn, bigRadix)
-		bn.Add(bn, bnChar)
-	}
 
-	// Get bignum as little endian data
-	tmpBytes := bn.Bytes()
-	
-	// Trim off sign byte if present
-	if len(tmpBytes) >= 2 && tmpBytes[0] == 0 && tmpBytes[1] >= 0x80 {
-		tmpBytes = tmpBytes[1:]
+		carry := idx
+		digits := 0
+		for k := size - 1; (carry != 0 || digits < length) && k >= 0; k-- {
+			carry += 58 * int(buf[k])
+			buf[k] = byte(carry % 256)
+			carry /= 256
+			digits++
+		}
+		length = digits
 	}
 
-	// Restore leading zeros
-	leadingZeros := 0
-	for _, c := range s {
-		if c == rune(base58Alphabet[0]) {
-			leadingZeros++
-		} else {
-			break
-		}
+	skip := size - length
+	for skip < size && buf[skip] == 0 {
+		skip++
 	}
 
-	result := make([]byte, leadingZeros+len(tmpBytes))
-	
-	// Convert little endian data to big endian
-	copy(result[leadingZeros:], reverse(tmpBytes))
-	
+	result := make([]byte, zeroes+(size-skip))
+	copy(result[zeroes:], buf[skip:])
 	return result, nil
 }
 
@@ -139,25 +157,89 @@ func Hash(data []byte) Hash256 {
 	return Hash256(second)
 }
 
-// Hash160 performs SHA256 followed by RIPEMD160 (simplified to just SHA256 for this implementation)
+// HashRIPEMD160 performs SHA256 followed by RIPEMD160, i.e. Bitcoin's Hash160.
 func HashRIPEMD160(data []byte) Hash160 {
-	// In the original Bitcoin implementation, this would be SHA256 followed by RIPEMD160
-	// For simplicity in this Go implementation, we'll use SHA256 and take first 20 bytes
-	hash := sha256.Sum256(data)
+	sha := sha256.Sum256(data)
+
+	ripemd := ripemd160.New()
+	ripemd.Write(sha[:]) // never returns an error
+	sum := ripemd.Sum(nil)
+
 	var result Hash160
-	copy(result[:], hash[:20])
+	copy(result[:], sum)
 	return result
 }
 
+// isOnCurve reports whether (x, y) satisfies the secp256k1 curve equation
+// y^2 = x^3 + 7 (mod p).
+func isOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || y.Sign() < 0 || x.Cmp(secp256k1P) >= 0 || y.Cmp(secp256k1P) >= 0 {
+		return false
+	}
+
+	lhs := new(big.Int).Mul(y, y)
+	lhs.Mod(lhs, secp256k1P)
+
+	rhs := new(big.Int).Mul(x, x)
+	rhs.Mul(rhs, x)
+	rhs.Add(rhs, secp256k1B)
+	rhs.Mod(rhs, secp256k1P)
+
+	return lhs.Cmp(rhs) == 0
+}
+
+// marshalPubPoint serializes a secp256k1 public point as an uncompressed
+// (0x04 || X || Y) or compressed (0x02/0x03 || X) public key.
+func marshalPubPoint(x, y *big.Int, compressed bool) ([]byte, error) {
+	if !isOnCurve(x, y) {
+		return nil, ErrPointNotOnCurve
+	}
+
+	xBytes := make([]byte, 32)
+	x.FillBytes(xBytes)
+
+	if !compressed {
+		yBytes := make([]byte, 32)
+		y.FillBytes(yBytes)
+
+		pubKey := make([]byte, 65)
+		pubKey[0] = 0x04
+		copy(pubKey[1:33], xBytes)
+		copy(pubKey[33:], yBytes)
+		return pubKey, nil
+	}
+
+	pubKey := make([]byte, 33)
+	if y.Bit(0) == 0 {
+		pubKey[0] = 0x02
+	} else {
+		pubKey[0] = 0x03
+	}
+	copy(pubKey[1:], xBytes)
+	return pubKey, nil
+}
+
+// PubPointToAddress converts a secp256k1 public point (X, Y) into a Bitcoin
+// P2PKH address, verifying that the point actually lies on the curve. When
+// compressed is true, the point is hashed in its compressed
+// (0x02/0x03 || X) form rather than the uncompressed (0x04 || X || Y) form.
+func PubPointToAddress(x, y *big.Int, compressed bool) (string, error) {
+	pubKey, err := marshalPubPoint(x, y, compressed)
+	if err != nil {
+		return "", err
+	}
+	return PubKeyToAddress(pubKey), nil
+}
+
 // EncodeBase58Check encodes with 4-byte checksum
 func EncodeBase58Check(input []byte) string {
 	// Add 4-byte hash check to the end
 	payload := make([]byte, len(input))
 	copy(payload, input)
-	
+
 	hash := Hash(payload)
 	payload = append(payload, hash[:4]...)
-	
+
 	return EncodeBase58(payload)
 }
 
@@ -167,56 +249,37 @@ func DecodeBase58Check(s string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	
+
 	if len(decoded) < 4 {
 		return nil, errors.New("decoded data too short")
 	}
-	
+
 	// Verify checksum
 	payload := decoded[:len(decoded)-4]
 	checksum := decoded[len(decoded)-4:]
-	
+
 	hash := Hash(payload)
 	if !bytesEqual(hash[:4], checksum) {
 		return nil, errors.New("checksum mismatch")
 	}
-	
+
 	return payload, nil
 }
 
-// Hash160ToAddress converts a 160-bit hash to a Bitcoin address
+// Hash160ToAddress converts a 160-bit hash to a mainnet P2PKH Bitcoin address.
 func Hash160ToAddress(hash160 Hash160) string {
-	// Add 1-byte version number to the front
-	payload := make([]byte, 1+len(hash160))
-	payload[0] = addressVersion
-	copy(payload[1:], hash160[:])
-	
-	return EncodeBase58Check(payload)
+	return EncodeAddress(hash160, MainNet, AddressTypeP2PKH)
 }
 
-// AddressToHash160 converts a Bitcoin address to a 160-bit hash
+// AddressToHash160 converts a mainnet P2PKH Bitcoin address to a 160-bit hash.
 func AddressToHash160(address string) (Hash160, error) {
-	var hash160 Hash160
-	
-	decoded, err := DecodeBase58Check(address)
+	hash160, net, typ, err := DecodeAddress(address)
 	if err != nil {
 		return hash160, err
 	}
-	
-	if len(decoded) == 0 {
-		return hash160, errors.New("empty decoded data")
-	}
-	
-	version := decoded[0]
-	if len(decoded) != len(hash160)+1 {
-		return hash160, errors.New("invalid address length")
+	if net != MainNet || typ != AddressTypeP2PKH {
+		return Hash160{}, errors.New("not a mainnet P2PKH address")
 	}
-	
-	if version > addressVersion {
-		return hash160, errors.New("invalid address version")
-	}
-	
-	copy(hash160[:], decoded[1:])
 	return hash160, nil
 }
 
@@ -234,24 +297,6 @@ func PubKeyToAddress(pubKey []byte) string {
 
 // Helper functions
 
-// reverse reverses a byte slice
-func reverse(data []byte) []byte {
-	result := make([]byte, len(data))
-	for i, b := range data {
-		result[len(data)-1-i] = b
-	}
-	return result
-}
-
-// reverseString reverses a string
-func reverseString(s string) string {
-	runes := []rune(s)
-	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
-		runes[i], runes[j] = runes[j], runes[i]
-	}
-	return string(runes)
-}
-
 // bytesEqual compares two byte slices for equality
 func bytesEqual(a, b []byte) bool {
 	if len(a) != len(b) {
@@ -264,44 +309,3 @@ func bytesEqual(a, b []byte) bool {
 	}
 	return true
 }
-
-// Example usage and tests
-func main() {
-	// Test Base58 encoding/decoding
-	testData := []byte("Hello, Bitcoin!")
-	encoded := EncodeBase58(testData)
-	fmt.Printf("Original: %s\n", testData)
-	fmt.Printf("Base58 Encoded: %s\n", encoded)
-	
-	decoded, err := DecodeBase58(encoded)
-	if err != nil {
-		fmt.Printf("Decode error: %v\n", err)
-		return
-	}
-	fmt.Printf("Decoded: %s\n", decoded)
-	
-	// Test Base58Check encoding/decoding
-	encodedCheck := EncodeBase58Check(testData)
-	fmt.Printf("Base58Check Encoded: %s\n", encodedCheck)
-	
-	decodedCheck, err := DecodeBase58Check(encodedCheck)
-	if err != nil {
-		fmt.Printf("DecodeCheck error: %v\n", err)
-		return
-	}
-	fmt.Printf("Decoded Check: %s\n", decodedCheck)
-	
-	// Test address generation
-	samplePubKey := []byte("sample public key data for testing")
-	address := PubKeyToAddress(samplePubKey)
-	fmt.Printf("Generated Address: %s\n", address)
-	fmt.Printf("Address is valid: %t\n", IsValidBitcoinAddress(address))
-	
-	// Test address to hash160 conversion
-	hash160, err := AddressToHash160(address)
-	if err != nil {
-		fmt.Printf("Address to Hash160 error: %v\n", err)
-		return
-	}
-	fmt.Printf("Hash160 from address: %x\n", hash160)
-}
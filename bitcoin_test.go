@@ -0,0 +1,67 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package bitcoin
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestPubPointToAddress checks the canonical Rosetta Code secp256k1
+// public-point-to-address vector.
+func TestPubPointToAddress(t *testing.T) {
+	x, ok := new(big.Int).SetString("50863AD64A87AE8A2FE83C1AF1A8403CB53F53E486D8511DAD8A04887E5B2352", 16)
+	if !ok {
+		t.Fatal("invalid X fixture")
+	}
+	y, ok := new(big.Int).SetString("2CD470243453A299FA9E77237716103ABC11A1DF38855ED6F2EE187E9C582BA6", 16)
+	if !ok {
+		t.Fatal("invalid Y fixture")
+	}
+
+	const want = "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"
+
+	got, err := PubPointToAddress(x, y, false)
+	if err != nil {
+		t.Fatalf("PubPointToAddress returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("PubPointToAddress = %q, want %q", got, want)
+	}
+}
+
+// TestPubPointToAddressCompressed checks the same point hashed via its
+// compressed (0x02/0x03 || X) serialization.
+func TestPubPointToAddressCompressed(t *testing.T) {
+	x, ok := new(big.Int).SetString("50863AD64A87AE8A2FE83C1AF1A8403CB53F53E486D8511DAD8A04887E5B2352", 16)
+	if !ok {
+		t.Fatal("invalid X fixture")
+	}
+	y, ok := new(big.Int).SetString("2CD470243453A299FA9E77237716103ABC11A1DF38855ED6F2EE187E9C582BA6", 16)
+	if !ok {
+		t.Fatal("invalid Y fixture")
+	}
+
+	const want = "1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAs"
+
+	got, err := PubPointToAddress(x, y, true)
+	if err != nil {
+		t.Fatalf("PubPointToAddress returned error: %v", err)
+	}
+	if got != want {
+		t.Errorf("PubPointToAddress(compressed) = %q, want %q", got, want)
+	}
+}
+
+// TestPubPointToAddressOffCurve checks that a point not satisfying
+// y^2 = x^3 + 7 (mod p) is rejected rather than silently hashed.
+func TestPubPointToAddressOffCurve(t *testing.T) {
+	x := big.NewInt(1)
+	y := big.NewInt(1) // 1^2 != 1^3 + 7 (mod p)
+
+	if _, err := PubPointToAddress(x, y, false); err != ErrPointNotOnCurve {
+		t.Errorf("PubPointToAddress(off-curve) = %v, want ErrPointNotOnCurve", err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+// Command bitcoingo exercises the bitcoin package's primitives end to end.
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/holynakamoto/bitcoingo"
+	"github.com/holynakamoto/bitcoingo/extkeys"
+)
+
+// Example usage and tests
+func main() {
+	// Test Base58 encoding/decoding
+	testData := []byte("Hello, Bitcoin!")
+	encoded := bitcoin.EncodeBase58(testData)
+	fmt.Printf("Original: %s\n", testData)
+	fmt.Printf("Base58 Encoded: %s\n", encoded)
+
+	decoded, err := bitcoin.DecodeBase58(encoded)
+	if err != nil {
+		fmt.Printf("Decode error: %v\n", err)
+		return
+	}
+	fmt.Printf("Decoded: %s\n", decoded)
+
+	// Test Base58Check encoding/decoding
+	encodedCheck := bitcoin.EncodeBase58Check(testData)
+	fmt.Printf("Base58Check Encoded: %s\n", encodedCheck)
+
+	decodedCheck, err := bitcoin.DecodeBase58Check(encodedCheck)
+	if err != nil {
+		fmt.Printf("DecodeCheck error: %v\n", err)
+		return
+	}
+	fmt.Printf("Decoded Check: %s\n", decodedCheck)
+
+	// Test address generation
+	samplePubKey := []byte("sample public key data for testing")
+	address := bitcoin.PubKeyToAddress(samplePubKey)
+	fmt.Printf("Generated Address: %s\n", address)
+	fmt.Printf("Address is valid: %t\n", bitcoin.IsValidBitcoinAddress(address))
+
+	// Test address to hash160 conversion
+	hash160, err := bitcoin.AddressToHash160(address)
+	if err != nil {
+		fmt.Printf("Address to Hash160 error: %v\n", err)
+		return
+	}
+	fmt.Printf("Hash160 from address: %x\n", hash160)
+
+	// Rosetta Code test vector: secp256k1 point to Bitcoin address.
+	x, _ := new(big.Int).SetString("50863AD64A87AE8A2FE83C1AF1A8403CB53F53E486D8511DAD8A04887E5B2352", 16)
+	y, _ := new(big.Int).SetString("2CD470243453A299FA9E77237716103ABC11A1DF38855ED6F2EE187E9C582BA6", 16)
+	rosettaAddress, err := bitcoin.PubPointToAddress(x, y, false)
+	if err != nil {
+		fmt.Printf("PubPointToAddress error: %v\n", err)
+		return
+	}
+	const wantRosettaAddress = "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"
+	fmt.Printf("Rosetta Code address: %s (want %s, match=%t)\n", rosettaAddress, wantRosettaAddress, rosettaAddress == wantRosettaAddress)
+
+	compressedAddress, err := bitcoin.PubPointToAddress(x, y, true)
+	if err != nil {
+		fmt.Printf("PubPointToAddress (compressed) error: %v\n", err)
+		return
+	}
+	fmt.Printf("Rosetta Code address (compressed pubkey): %s\n", compressedAddress)
+
+	// Test multi-network address and WIF encoding.
+	testnetAddress := bitcoin.EncodeAddress(hash160, bitcoin.TestNet, bitcoin.AddressTypeP2PKH)
+	fmt.Printf("Testnet address: %s\n", testnetAddress)
+
+	samplePrivKey := bitcoin.Hash(samplePubKey) // stand-in 32-byte value, not a real derivation
+	wif, err := bitcoin.EncodeWIF(samplePrivKey[:], bitcoin.MainNet, true)
+	if err != nil {
+		fmt.Printf("EncodeWIF error: %v\n", err)
+		return
+	}
+	fmt.Printf("WIF: %s\n", wif)
+
+	// Test BIP-32 hierarchical deterministic derivation (BIP-32 test vector 1 seed).
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := extkeys.NewMaster(seed)
+	if err != nil {
+		fmt.Printf("NewMaster error: %v\n", err)
+		return
+	}
+	fmt.Printf("Master xprv: %s\n", master.String())
+
+	masterPub, err := master.Neuter()
+	if err != nil {
+		fmt.Printf("Neuter error: %v\n", err)
+		return
+	}
+	fmt.Printf("Master xpub: %s\n", masterPub.String())
+
+	child, err := extkeys.Derive(master, "m/0'/1")
+	if err != nil {
+		fmt.Printf("Derive error: %v\n", err)
+		return
+	}
+	fmt.Printf("m/0'/1 xprv: %s\n", child.String())
+
+	// Test native SegWit (Bech32/Bech32m) address encoding.
+	segwitAddress, err := bitcoin.EncodeSegWit(bitcoin.MainNet.Bech32HRP, 0, hash160[:])
+	if err != nil {
+		fmt.Printf("EncodeSegWit error: %v\n", err)
+		return
+	}
+	fmt.Printf("SegWit v0 address: %s\n", segwitAddress)
+
+	parsed, err := bitcoin.ParseAddress(segwitAddress)
+	if err != nil {
+		fmt.Printf("ParseAddress error: %v\n", err)
+		return
+	}
+	fmt.Printf("Parsed SegWit address: version=%d program=%x\n", parsed.WitnessVersion, parsed.WitnessProgram)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+// Package extkeys implements BIP-32 hierarchical deterministic key
+// derivation on top of the bitcoin package's hash and Base58Check
+// primitives.
+package extkeys
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/holynakamoto/bitcoingo"
+)
+
+// HardenedOffset is the child index at and above which derivation is
+// hardened (BIP-32 index' notation).
+const HardenedOffset = uint32(0x80000000)
+
+// Mainnet extended-key version bytes, as defined by BIP-32 / SLIP-132.
+var (
+	xprvVersion = [4]byte{0x04, 0x88, 0xAD, 0xE4}
+	xpubVersion = [4]byte{0x04, 0x88, 0xB2, 0x1E}
+)
+
+var (
+	ErrInvalidSeed        = errors.New("extkeys: invalid seed, resulting master key is invalid")
+	ErrInvalidChild       = errors.New("extkeys: invalid child, caller should try the next index")
+	ErrHardenedFromPublic = errors.New("extkeys: cannot derive a hardened child from a public key")
+)
+
+// ExtendedKey is a BIP-32 node: either an extended private key or, after
+// Neuter, an extended public key.
+type ExtendedKey struct {
+	version           [4]byte
+	depth             byte
+	parentFingerprint [4]byte
+	childNumber       uint32
+	chainCode         [32]byte
+	key               [33]byte // 0x00||privkey for private nodes, compressed pubkey for public nodes
+	isPrivate         bool
+}
+
+// NewMaster derives the master extended private key from a BIP-32 seed, per
+// "I = HMAC-SHA512(key = "Bitcoin seed", data = seed)".
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+
+	il, ir := i[:32], i[32:]
+
+	privKeyNum := new(big.Int).SetBytes(il)
+	if privKeyNum.Sign() == 0 || privKeyNum.Cmp(btcec.S256().N) >= 0 {
+		return nil, ErrInvalidSeed
+	}
+
+	k := &ExtendedKey{
+		version:   xprvVersion,
+		isPrivate: true,
+	}
+	k.key[0] = 0x00
+	copy(k.key[1:], il)
+	copy(k.chainCode[:], ir)
+
+	return k, nil
+}
+
+// IsPrivate reports whether k carries a private key.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.isPrivate
+}
+
+// Child derives the child extended key at the given index. Indices at or
+// above HardenedOffset request hardened derivation, which requires k to hold
+// a private key.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	hardened := index >= HardenedOffset
+	if hardened && !k.isPrivate {
+		return nil, ErrHardenedFromPublic
+	}
+
+	pubKey, err := k.pubKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(data, k.key[:]...) // 0x00 || 32-byte private key
+	} else {
+		data = append(data, pubKey[:]...)
+	}
+	var idxBytes [4]byte
+	binary.BigEndian.PutUint32(idxBytes[:], index)
+	data = append(data, idxBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write(data)
+	i := mac.Sum(nil)
+	il, ir := i[:32], i[32:]
+
+	ilNum := new(big.Int).SetBytes(il)
+	curve := btcec.S256()
+	if ilNum.Cmp(curve.N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+
+	child := &ExtendedKey{
+		depth:       k.depth + 1,
+		childNumber: index,
+		isPrivate:   k.isPrivate,
+	}
+	copy(child.chainCode[:], ir)
+
+	fingerprint := bitcoin.HashRIPEMD160(pubKey[:])
+	copy(child.parentFingerprint[:], fingerprint[:4])
+
+	if k.isPrivate {
+		child.version = xprvVersion
+
+		parentKeyNum := new(big.Int).SetBytes(k.key[1:])
+		childKeyNum := new(big.Int).Add(ilNum, parentKeyNum)
+		childKeyNum.Mod(childKeyNum, curve.N)
+		if childKeyNum.Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
+
+		child.key[0] = 0x00
+		childKeyNum.FillBytes(child.key[1:])
+		return child, nil
+	}
+
+	child.version = xpubVersion
+
+	parentPubKey, err := btcec.ParsePubKey(pubKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	ilX, ilY := curve.ScalarBaseMult(il)
+	childX, childY := curve.Add(parentPubKey.X(), parentPubKey.Y(), ilX, ilY)
+	if childX.Sign() == 0 && childY.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+
+	childPubKey, err := compressPoint(childX, childY)
+	if err != nil {
+		return nil, err
+	}
+	child.key = childPubKey
+
+	return child, nil
+}
+
+// Neuter converts a private extended key into the corresponding extended
+// public key, stripping the private key material. It is a no-op on an
+// already-public key.
+func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
+	if !k.isPrivate {
+		return k, nil
+	}
+
+	pubKey, err := k.pubKeyBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtendedKey{
+		version:           xpubVersion,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childNumber:       k.childNumber,
+		chainCode:         k.chainCode,
+		key:               pubKey,
+		isPrivate:         false,
+	}, nil
+}
+
+// pubKeyBytes returns the 33-byte compressed public key for k, deriving it
+// from the private key if necessary.
+func (k *ExtendedKey) pubKeyBytes() ([33]byte, error) {
+	if !k.isPrivate {
+		return k.key, nil
+	}
+
+	_, pubKey := btcec.PrivKeyFromBytes(k.key[1:])
+	var out [33]byte
+	copy(out[:], pubKey.SerializeCompressed())
+	return out, nil
+}
+
+// String serializes k as standard Base58Check-encoded xprv/xpub.
+func (k *ExtendedKey) String() string {
+	buf := make([]byte, 0, 78)
+	buf = append(buf, k.version[:]...)
+	buf = append(buf, k.depth)
+	buf = append(buf, k.parentFingerprint[:]...)
+
+	var childNumBytes [4]byte
+	binary.BigEndian.PutUint32(childNumBytes[:], k.childNumber)
+	buf = append(buf, childNumBytes[:]...)
+
+	buf = append(buf, k.chainCode[:]...)
+	buf = append(buf, k.key[:]...)
+
+	return bitcoin.EncodeBase58Check(buf)
+}
+
+// compressPoint serializes a secp256k1 point as a 33-byte compressed public
+// key (0x02/0x03 || X).
+func compressPoint(x, y *big.Int) ([33]byte, error) {
+	var out [33]byte
+	if y.Bit(0) == 0 {
+		out[0] = 0x02
+	} else {
+		out[0] = 0x03
+	}
+	x.FillBytes(out[1:])
+	return out, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package extkeys
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// bip32Vector1Seed is the seed for BIP-32 test vector 1.
+const bip32Vector1Seed = "000102030405060708090a0b0c0d0e0f"
+
+// bip32Vector1 walks m -> m/0' -> m/0'/1 -> m/0'/1/2' -> m/0'/1/2'/2 ->
+// m/0'/1/2'/2/1000000000, matching the standard BIP-32 test vector 1 chain.
+var bip32Vector1 = []struct {
+	path string
+	xprv string
+	xpub string
+}{
+	{
+		"m",
+		"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+	},
+	{
+		"m/0'",
+		"xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+		"xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+	},
+	{
+		"m/0'/1",
+		"xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+		"xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+	},
+	{
+		"m/0'/1/2'",
+		"xprv9z4pot5VBttmtdRTWfWQmoH1taj2axGVzFqSb8C9xaxKymcFzXBDptWmT7FwuEzG3ryjH4ktypQSAewRiNMjANTtpgP4mLTj34bhnZX7UiM",
+		"xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5",
+	},
+	{
+		"m/0'/1/2'/2",
+		"xprvA2JDeKCSNNZky6uBCviVfJSKyQ1mDYahRjijr5idH2WwLsEd4Hsb2Tyh8RfQMuPh7f7RtyzTtdrbdqqsunu5Mm3wDvUAKRHSC34sJ7in334",
+		"xpub6FHa3pjLCk84BayeJxFW2SP4XRrFd1JYnxeLeU8EqN3vDfZmbqBqaGJAyiLjTAwm6ZLRQUMv1ZACTj37sR62cfN7fe5JnJ7dh8zL4fiyLHV",
+	},
+	{
+		"m/0'/1/2'/2/1000000000",
+		"xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76",
+		"xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy",
+	},
+}
+
+func TestBIP32Vector1(t *testing.T) {
+	seed, err := hex.DecodeString(bip32Vector1Seed)
+	if err != nil {
+		t.Fatalf("invalid seed fixture: %v", err)
+	}
+
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatalf("NewMaster returned error: %v", err)
+	}
+
+	for _, step := range bip32Vector1 {
+		key, err := Derive(master, step.path)
+		if err != nil {
+			t.Fatalf("Derive(%s) returned error: %v", step.path, err)
+		}
+
+		if got := key.String(); got != step.xprv {
+			t.Errorf("Derive(%s).String() = %s, want %s", step.path, got, step.xprv)
+		}
+
+		pub, err := key.Neuter()
+		if err != nil {
+			t.Fatalf("%s: Neuter returned error: %v", step.path, err)
+		}
+		if got := pub.String(); got != step.xpub {
+			t.Errorf("Derive(%s).Neuter().String() = %s, want %s", step.path, got, step.xpub)
+		}
+	}
+}
+
+func TestChildHardenedFromPublicKeyFails(t *testing.T) {
+	seed, err := hex.DecodeString(bip32Vector1Seed)
+	if err != nil {
+		t.Fatalf("invalid seed fixture: %v", err)
+	}
+
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatalf("NewMaster returned error: %v", err)
+	}
+
+	pub, err := master.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter returned error: %v", err)
+	}
+
+	if _, err := pub.Child(HardenedOffset); err != ErrHardenedFromPublic {
+		t.Errorf("Child(HardenedOffset) on a public key = %v, want ErrHardenedFromPublic", err)
+	}
+}
+
+func TestParsePathRejectsInvalidSegments(t *testing.T) {
+	cases := []string{
+		"44'/0'/0'/0/0", // missing leading "m"
+		"m/notanumber",
+		"m/2147483648", // HardenedOffset itself is out of range unhardened
+	}
+
+	for _, path := range cases {
+		if _, err := ParsePath(path); err == nil {
+			t.Errorf("ParsePath(%q) succeeded, want error", path)
+		}
+	}
+}
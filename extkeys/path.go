@@ -0,0 +1,63 @@
+// Copyright (c) 2009 Satoshi Nakamoto
+// Distributed under the MIT/X11 software license, see the accompanying
+// file license.txt or http://www.opensource.org/licenses/mit-license.php.
+
+package extkeys
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePath parses a BIP-32 derivation path such as "m/44'/0'/0'/0/0" into
+// the sequence of child indices it names, with hardened segments (trailing
+// ' or h) offset by HardenedOffset.
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if len(segments) == 0 || segments[0] != "m" {
+		return nil, fmt.Errorf("extkeys: path %q must start with \"m\"", path)
+	}
+
+	indices := make([]uint32, 0, len(segments)-1)
+	for _, seg := range segments[1:] {
+		hardened := strings.HasSuffix(seg, "'") || strings.HasSuffix(seg, "h") || strings.HasSuffix(seg, "H")
+		numPart := seg
+		if hardened {
+			numPart = seg[:len(seg)-1]
+		}
+
+		n, err := strconv.ParseUint(numPart, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("extkeys: invalid path segment %q: %w", seg, err)
+		}
+		if uint32(n) >= HardenedOffset {
+			return nil, fmt.Errorf("extkeys: path segment %q out of range", seg)
+		}
+
+		index := uint32(n)
+		if hardened {
+			index += HardenedOffset
+		}
+		indices = append(indices, index)
+	}
+
+	return indices, nil
+}
+
+// Derive walks master through each index named by path, in order.
+func Derive(master *ExtendedKey, path string) (*ExtendedKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key := master
+	for _, index := range indices {
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}